@@ -2,16 +2,183 @@ package github
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v69/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// dependencyReviewCommentMarker identifies comments this tool authored so a later run
+// can update them in place instead of piling up duplicate comments.
+const dependencyReviewCommentMarker = "<!-- github-mcp-server:dep-review -->"
+
+// severityBadges renders a short, readable badge for each dependency-review severity.
+var severityBadges = map[string]string{
+	"critical": "🔴 critical",
+	"high":     "🟠 high",
+	"moderate": "🟡 moderate",
+	"low":      "⚪ low",
+}
+
+// defaultScorecardChecks are the OSSF Scorecard checks surfaced when the caller does not
+// supply scorecard_checks explicitly.
+var defaultScorecardChecks = []string{
+	"Maintained",
+	"Code-Review",
+	"Dangerous-Workflow",
+	"Pinned-Dependencies",
+	"Vulnerabilities",
+	"Signed-Releases",
+}
+
+// scorecardWorkerCount bounds the number of concurrent requests issued against the
+// OSSF Scorecard API when enriching a dependency comparison.
+const scorecardWorkerCount = 8
+
+// scorecardCache memoizes OSSF Scorecard lookups per owner/repo for the lifetime of the
+// process, since the same dependency is commonly re-evaluated across tool calls.
+var scorecardCache sync.Map
+
+// scorecardHTTPClient issues requests against the OSSF Scorecard API. It is a variable
+// so tests can point it at a mock server.
+var scorecardHTTPClient = http.DefaultClient
+
+// scorecardAPIBaseURL is the root of the OSSF Scorecard API. It is a variable so tests
+// can point it at a mock server.
+var scorecardAPIBaseURL = "https://api.securityscorecards.dev/projects/github.com"
+
+// scorecardAPIResponse mirrors the subset of api.securityscorecards.dev's response this
+// tool cares about.
+type scorecardAPIResponse struct {
+	Date   string  `json:"date"`
+	Score  float64 `json:"score"`
+	Checks []struct {
+		Name  string  `json:"name"`
+		Score float64 `json:"score"`
+	} `json:"checks"`
+}
+
+// severityRank orders dependency-review severities from least to most severe so a
+// fail_on_severity floor can be compared against a reported severity.
+var severityRank = map[string]int{
+	"low":      0,
+	"moderate": 1,
+	"high":     2,
+	"critical": 3,
+}
+
+// resolveRepoAndBasehead determines the owner, repo, and basehead a dependency review
+// tool should operate on, accepting either an explicit owner+repo+basehead triple or a
+// single repo_url reference such as https://github.com/octocat/Hello-World/compare/main...feature
+// or the octocat/Hello-World@main...feature shorthand. An explicit owner, repo, or
+// basehead always takes precedence over what repo_url implies.
+func resolveRepoAndBasehead(request mcp.CallToolRequest) (owner, repo, basehead string, err error) {
+	repoURL, err := OptionalParam[string](request, "repo_url")
+	if err != nil {
+		return "", "", "", err
+	}
+	if repoURL != "" {
+		ref, parseErr := ParseRepoRef(repoURL)
+		if parseErr != nil {
+			return "", "", "", parseErr
+		}
+		owner, repo = ref.Owner, ref.Repo
+		basehead = extractBasehead(repoURL)
+	}
+
+	if explicitOwner, paramErr := OptionalParam[string](request, "owner"); paramErr != nil {
+		return "", "", "", paramErr
+	} else if explicitOwner != "" {
+		owner = explicitOwner
+	}
+	if explicitRepo, paramErr := OptionalParam[string](request, "repo"); paramErr != nil {
+		return "", "", "", paramErr
+	} else if explicitRepo != "" {
+		repo = explicitRepo
+	}
+	if explicitBasehead, paramErr := OptionalParam[string](request, "basehead"); paramErr != nil {
+		return "", "", "", paramErr
+	} else if explicitBasehead != "" {
+		basehead = explicitBasehead
+	}
+
+	if owner == "" || repo == "" {
+		return "", "", "", fmt.Errorf("missing required parameter: owner and repo, or a repo_url identifying them")
+	}
+	if basehead == "" {
+		return "", "", "", fmt.Errorf("missing required parameter: basehead, or a repo_url containing a compare/basehead suffix")
+	}
+
+	return owner, repo, basehead, nil
+}
+
+// extractBasehead pulls a {base}...{head} comparison out of a repo_url, recognizing the
+// /compare/<basehead> URL suffix and the owner/repo@<basehead> shorthand suffix. It
+// returns "" if ref carries no basehead. The @ shorthand only applies to non-URL refs,
+// since a URL's @ may instead be userinfo (e.g. https://user@ghe.example.com/o/r).
+func extractBasehead(ref string) string {
+	if idx := strings.Index(ref, "/compare/"); idx != -1 {
+		return ref[idx+len("/compare/"):]
+	}
+	if strings.Contains(ref, "://") {
+		return ""
+	}
+	if _, after, ok := strings.Cut(ref, "@"); ok {
+		return after
+	}
+	return ""
+}
+
+// fetchDependencyChanges requests the dependency-graph compare endpoint shared by the
+// dependency review tools and returns the decoded changes. A non-200 response yields an
+// MCP error result; client, request, and transport failures are returned as an error.
+func fetchDependencyChanges(ctx context.Context, getClient GetClientFn, owner, repo, basehead, name string) ([]map[string]interface{}, *mcp.CallToolResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	url := fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s", owner, repo, basehead)
+	if name != "" {
+		// If a manifest file is specified, add it as a query parameter
+		url = fmt.Sprintf("%s?name=%s", url, name)
+	}
+
+	req, err := client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var dependencyChanges []map[string]interface{}
+	resp, err := client.Do(ctx, req, &dependencyChanges)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get dependency changes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to get dependency changes: %s", string(body))), nil
+	}
+
+	return dependencyChanges, nil, nil
+}
+
 // GetDependencyReviewCompare provides a tool to compare dependencies between two commits
 func GetDependencyReviewCompare(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_dependency_review_compare",
@@ -21,31 +188,199 @@ func GetDependencyReviewCompare(getClient GetClientFn, t translations.Translatio
 				ReadOnlyHint: toBoolPtr(true),
 			}),
 			mcp.WithString("owner",
-				mcp.Required(),
-				mcp.Description("The account owner of the repository."),
+				mcp.Description("The account owner of the repository. Required unless repo_url is given."),
 			),
 			mcp.WithString("repo",
-				mcp.Required(),
-				mcp.Description("The name of the repository."),
+				mcp.Description("The name of the repository. Required unless repo_url is given."),
 			),
 			mcp.WithString("basehead",
-				mcp.Required(),
-				mcp.Description("The base and head Git revisions to compare in the format {base}...{head}."),
+				mcp.Description("The base and head Git revisions to compare in the format {base}...{head}. Required unless repo_url carries a compare suffix."),
+			),
+			mcp.WithString("repo_url",
+				mcp.Description("A GitHub repository URL or owner/repo shorthand, optionally carrying the comparison, e.g. https://github.com/octocat/Hello-World/compare/main...feature or octocat/Hello-World@main...feature. Alternative to owner+repo(+basehead)."),
 			),
 			mcp.WithString("name",
 				mcp.Description("The full path, relative to the repository root, of the dependency manifest file."),
 			),
+			mcp.WithBoolean("include_scorecard",
+				mcp.Description("When true, attach an OSSF Scorecard result to each added dependency that has a source_repository_url on GitHub."),
+			),
+			mcp.WithArray("scorecard_checks",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("OSSF Scorecard check names to include in the scorecard summary. Defaults to Maintained, Code-Review, Dangerous-Workflow, Pinned-Dependencies, Vulnerabilities, Signed-Releases."),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			owner, err := requiredParam[string](request, "owner")
+			owner, repo, basehead, err := resolveRepoAndBasehead(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			repo, err := requiredParam[string](request, "repo")
+			includeScorecard, err := OptionalParam[bool](request, "include_scorecard")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			basehead, err := requiredParam[string](request, "basehead")
+			scorecardChecks, err := OptionalStringArrayParam(request, "scorecard_checks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(scorecardChecks) == 0 {
+				scorecardChecks = defaultScorecardChecks
+			}
+
+			dependencyChanges, errResult, err := fetchDependencyChanges(ctx, getClient, owner, repo, basehead, name)
+			if err != nil {
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			if includeScorecard {
+				enrichWithScorecards(ctx, dependencyChanges, scorecardChecks)
+			}
+
+			result, err := json.Marshal(dependencyChanges)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal dependency changes: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(result)), nil
+		}
+}
+
+// enrichWithScorecards attaches a "scorecard" field to every added change whose
+// source_repository_url points at a GitHub repo, fetching results concurrently through
+// a bounded worker pool. Changes whose scorecard cannot be determined get a nil
+// "scorecard" field rather than failing the call.
+func enrichWithScorecards(ctx context.Context, changes []map[string]interface{}, checks []string) {
+	sem := make(chan struct{}, scorecardWorkerCount)
+	var wg sync.WaitGroup
+
+	for _, change := range changes {
+		if change["change_type"] != "added" {
+			continue
+		}
+		repoURL, _ := change["source_repository_url"].(string)
+		ref, parseErr := ParseRepoRef(repoURL)
+		if parseErr != nil || ref.Host != "github.com" {
+			change["scorecard"] = nil
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(change map[string]interface{}, owner, repo string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			change["scorecard"] = fetchScorecard(ctx, owner, repo, checks)
+		}(change, ref.Owner, ref.Repo)
+	}
+
+	wg.Wait()
+}
+
+// fetchScorecard retrieves (and caches) the OSSF Scorecard result for owner/repo,
+// narrowed to the requested checks. It returns nil if the scorecard is unavailable.
+func fetchScorecard(ctx context.Context, owner, repo string, checks []string) map[string]interface{} {
+	cacheKey := strings.ToLower(owner + "/" + repo)
+	if cached, ok := scorecardCache.Load(cacheKey); ok {
+		return summarizeScorecard(cached.(*scorecardAPIResponse), checks)
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s", scorecardAPIBaseURL, owner, repo)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := scorecardHTTPClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var scorecard scorecardAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scorecard); err != nil {
+		return nil
+	}
+
+	scorecardCache.Store(cacheKey, &scorecard)
+	return summarizeScorecard(&scorecard, checks)
+}
+
+// summarizeScorecard reduces a full scorecard API response down to the aggregate score,
+// date, and the caller-requested checks.
+func summarizeScorecard(scorecard *scorecardAPIResponse, checks []string) map[string]interface{} {
+	wanted := toStringSet(checks)
+	checkScores := map[string]float64{}
+	for _, check := range scorecard.Checks {
+		if wanted[check.Name] {
+			checkScores[check.Name] = check.Score
+		}
+	}
+
+	return map[string]interface{}{
+		"score":  scorecard.Score,
+		"date":   scorecard.Date,
+		"checks": checkScores,
+	}
+}
+
+// EvaluateDependencyReview provides a tool to grade a dependency comparison against a
+// caller-supplied merge policy, mirroring the gating behavior of the dependency-review
+// GitHub Action.
+func EvaluateDependencyReview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("evaluate_dependency_review",
+			mcp.WithDescription(t("TOOL_EVALUATE_DEPENDENCY_REVIEW_DESCRIPTION", "Compare dependencies between commits and grade the result against a merge policy.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_EVALUATE_DEPENDENCY_REVIEW_USER_TITLE", "Evaluate dependency review against a policy"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("The account owner of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("The name of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithString("basehead",
+				mcp.Description("The base and head Git revisions to compare in the format {base}...{head}. Required unless repo_url carries a compare suffix."),
+			),
+			mcp.WithString("repo_url",
+				mcp.Description("A GitHub repository URL or owner/repo shorthand, optionally carrying the comparison, e.g. https://github.com/octocat/Hello-World/compare/main...feature or octocat/Hello-World@main...feature. Alternative to owner+repo(+basehead)."),
+			),
+			mcp.WithString("name",
+				mcp.Description("The full path, relative to the repository root, of the dependency manifest file."),
+			),
+			mcp.WithString("fail_on_severity",
+				mcp.Description("The lowest vulnerability severity that should fail the policy: low, moderate, high, or critical. Defaults to low."),
+			),
+			mcp.WithArray("allow_licenses",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("SPDX license identifiers to allow. When non-empty, a dependency whose license is not listed here fails the policy. deny_licenses takes precedence over this list."),
+			),
+			mcp.WithArray("deny_licenses",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("SPDX license identifiers that always fail the policy."),
+			),
+			mcp.WithArray("allow_ghsas",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("GHSA advisory IDs to ignore when evaluating vulnerabilities."),
+			),
+			mcp.WithArray("allow_dependencies_licenses",
+				mcp.Items(map[string]interface{}{"type": "string"}),
+				mcp.Description("Package URLs (purl) that are exempt from license checks, regardless of allow_licenses/deny_licenses."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, basehead, err := resolveRepoAndBasehead(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -53,44 +388,646 @@ func GetDependencyReviewCompare(getClient GetClientFn, t translations.Translatio
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			failOnSeverity, err := OptionalParam[string](request, "fail_on_severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if failOnSeverity == "" {
+				failOnSeverity = "low"
+			}
+			floor, ok := severityRank[failOnSeverity]
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid fail_on_severity %q: must be one of low, moderate, high, critical", failOnSeverity)), nil
+			}
+			allowLicenses, err := OptionalStringArrayParam(request, "allow_licenses")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			denyLicenses, err := OptionalStringArrayParam(request, "deny_licenses")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowGHSAs, err := OptionalStringArrayParam(request, "allow_ghsas")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowDependenciesLicenses, err := OptionalStringArrayParam(request, "allow_dependencies_licenses")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-			client, err := getClient(ctx)
+			dependencyChanges, errResult, err := fetchDependencyChanges(ctx, getClient, owner, repo, basehead, name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
 			}
 
-			// Build the URL for the dependency review API
-			url := fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s", owner, repo, basehead)
-			if name != "" {
-				// If a manifest file is specified, add it as a query parameter
-				url = fmt.Sprintf("%s?name=%s", url, name)
+			allowLicenseSet := toStringSet(allowLicenses)
+			denyLicenseSet := toStringSet(denyLicenses)
+			allowGHSASet := toStringSet(allowGHSAs)
+
+			vulnerabilityViolations := []map[string]interface{}{}
+			licenseViolations := []map[string]interface{}{}
+			passingChanges := []map[string]interface{}{}
+
+			for _, change := range dependencyChanges {
+				if change["change_type"] == "removed" {
+					continue
+				}
+
+				purl, _ := change["package_url"].(string)
+				failed := false
+
+				if vulns, ok := change["vulnerabilities"].([]interface{}); ok {
+					for _, v := range vulns {
+						vuln, ok := v.(map[string]interface{})
+						if !ok {
+							continue
+						}
+						ghsaID, _ := vuln["advisory_ghsa_id"].(string)
+						if allowGHSASet[ghsaID] {
+							continue
+						}
+						severity, _ := vuln["severity"].(string)
+						if severityRank[severity] < floor {
+							continue
+						}
+						failed = true
+						vulnerabilityViolations = append(vulnerabilityViolations, map[string]interface{}{
+							"package_url":      purl,
+							"name":             change["name"],
+							"version":          change["version"],
+							"ecosystem":        change["ecosystem"],
+							"manifest":         change["manifest"],
+							"severity":         severity,
+							"advisory_ghsa_id": ghsaID,
+							"advisory_summary": vuln["advisory_summary"],
+							"advisory_url":     vuln["advisory_url"],
+						})
+					}
+				}
+
+				if !isLicenseExempt(purl, allowDependenciesLicenses) {
+					license, hasLicense := change["license"].(string)
+					switch {
+					case !hasLicense || license == "":
+						failed = true
+						licenseViolations = append(licenseViolations, map[string]interface{}{
+							"package_url": purl,
+							"name":        change["name"],
+							"version":     change["version"],
+							"ecosystem":   change["ecosystem"],
+							"manifest":    change["manifest"],
+							"reason":      "unknown_license",
+						})
+					case denyLicenseSet[license] || (len(allowLicenseSet) > 0 && !allowLicenseSet[license]):
+						failed = true
+						licenseViolations = append(licenseViolations, map[string]interface{}{
+							"package_url": purl,
+							"name":        change["name"],
+							"version":     change["version"],
+							"ecosystem":   change["ecosystem"],
+							"manifest":    change["manifest"],
+							"license":     license,
+							"reason":      "disallowed_license",
+						})
+					}
+				}
+
+				if !failed {
+					passingChanges = append(passingChanges, change)
+				}
 			}
 
-			req, err := client.NewRequest("GET", url, nil)
+			result, err := json.Marshal(map[string]interface{}{
+				"passed":                   len(vulnerabilityViolations) == 0 && len(licenseViolations) == 0,
+				"vulnerability_violations": vulnerabilityViolations,
+				"license_violations":       licenseViolations,
+				"passing_changes":          passingChanges,
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to create request: %w", err)
+				return nil, fmt.Errorf("failed to marshal dependency review evaluation: %w", err)
 			}
 
-			var dependencyChanges []map[string]interface{}
-			resp, err := client.Do(ctx, req, &dependencyChanges)
+			return mcp.NewToolResultText(string(result)), nil
+		}
+}
+
+// toStringSet builds a lookup set from a list of strings, ignoring empty entries.
+func toStringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// isLicenseExempt reports whether purl matches an entry in allowDependenciesLicenses,
+// comparing either the full package URL or its version-less prefix.
+func isLicenseExempt(purl string, allowDependenciesLicenses []string) bool {
+	if purl == "" {
+		return false
+	}
+	bare, _, _ := strings.Cut(purl, "@")
+	for _, exempt := range allowDependenciesLicenses {
+		if exempt == purl || exempt == bare {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDependencyReviewSBOM provides a tool to render a dependency comparison as a
+// CycloneDX or SPDX SBOM document.
+func GetDependencyReviewSBOM(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_dependency_review_sbom",
+			mcp.WithDescription(t("TOOL_GET_DEPENDENCY_REVIEW_SBOM_DESCRIPTION", "Get a dependency comparison between commits as a CycloneDX or SPDX SBOM document.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_DEPENDENCY_REVIEW_SBOM_USER_TITLE", "Get dependency review as an SBOM"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("The account owner of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("The name of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithString("basehead",
+				mcp.Description("The base and head Git revisions to compare in the format {base}...{head}. Required unless repo_url carries a compare suffix."),
+			),
+			mcp.WithString("repo_url",
+				mcp.Description("A GitHub repository URL or owner/repo shorthand, optionally carrying the comparison, e.g. https://github.com/octocat/Hello-World/compare/main...feature or octocat/Hello-World@main...feature. Alternative to owner+repo(+basehead)."),
+			),
+			mcp.WithString("name",
+				mcp.Description("The full path, relative to the repository root, of the dependency manifest file."),
+			),
+			mcp.WithString("format",
+				mcp.Description("The SBOM format to emit: cyclonedx-json or spdx-json. Defaults to cyclonedx-json."),
+			),
+			mcp.WithString("scope",
+				mcp.Description("Which changes to include: added, all, or net-new (added dependencies with no removed dependency of the same ecosystem and name in the comparison, e.g. excludes version bumps). Defaults to added."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, basehead, err := resolveRepoAndBasehead(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = "cyclonedx-json"
+			}
+			if format != "cyclonedx-json" && format != "spdx-json" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid format %q: must be one of cyclonedx-json, spdx-json", format)), nil
+			}
+			scope, err := OptionalParam[string](request, "scope")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if scope == "" {
+				scope = "added"
+			}
+			if scope != "added" && scope != "all" && scope != "net-new" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid scope %q: must be one of added, all, net-new", scope)), nil
+			}
+
+			dependencyChanges, errResult, err := fetchDependencyChanges(ctx, getClient, owner, repo, basehead, name)
+			if err != nil {
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			components := scopeDependencyChanges(dependencyChanges, scope)
+
+			var result []byte
+			if format == "spdx-json" {
+				result, err = json.Marshal(buildSPDXDocument(components))
+			} else {
+				result, err = json.Marshal(buildCycloneDXDocument(components))
+			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to get dependency changes: %w", err)
+				return nil, fmt.Errorf("failed to marshal SBOM document: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(result)), nil
+		}
+}
+
+// scopeDependencyChanges filters changes to the requested SBOM scope: added changes
+// only, every change regardless of type, or net-new changes (added packages whose
+// ecosystem+name pair does not also appear as a removed change in the comparison, e.g.
+// a version bump of an existing dependency is not net-new).
+func scopeDependencyChanges(changes []map[string]interface{}, scope string) []map[string]interface{} {
+	if scope == "all" {
+		return changes
+	}
+
+	removed := map[string]bool{}
+	for _, change := range changes {
+		if change["change_type"] == "removed" {
+			removed[fmt.Sprintf("%v/%v", change["ecosystem"], change["name"])] = true
+		}
+	}
+
+	filtered := make([]map[string]interface{}, 0, len(changes))
+	for _, change := range changes {
+		if change["change_type"] != "added" {
+			continue
+		}
+		if scope == "net-new" && removed[fmt.Sprintf("%v/%v", change["ecosystem"], change["name"])] {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// cycloneDXSeverities are the valid values of CycloneDX 1.5's vulnerability rating
+// severity enum. GitHub's "moderate" has no direct match, so it is mapped to "medium";
+// anything else unrecognized falls back to "unknown".
+var cycloneDXSeverities = map[string]string{
+	"critical": "critical",
+	"high":     "high",
+	"moderate": "medium",
+	"medium":   "medium",
+	"low":      "low",
+	"info":     "info",
+	"none":     "none",
+	"unknown":  "unknown",
+}
+
+// cycloneDXSeverity maps a GitHub advisory severity to a CycloneDX 1.5 rating severity.
+func cycloneDXSeverity(severity string) string {
+	if mapped, ok := cycloneDXSeverities[strings.ToLower(severity)]; ok {
+		return mapped
+	}
+	return "unknown"
+}
+
+// buildCycloneDXDocument renders the given dependency changes as a CycloneDX 1.5 SBOM.
+func buildCycloneDXDocument(changes []map[string]interface{}) map[string]interface{} {
+	components := make([]map[string]interface{}, 0, len(changes))
+	vulnerabilities := make([]map[string]interface{}, 0)
+
+	for _, change := range changes {
+		purl, _ := change["package_url"].(string)
+		bomRef := purl
+		if bomRef == "" {
+			bomRef = fmt.Sprintf("%v@%v", change["name"], change["version"])
+		}
+
+		component := map[string]interface{}{
+			"type":    "library",
+			"bom-ref": bomRef,
+			"name":    change["name"],
+			"version": change["version"],
+		}
+		if purl != "" {
+			component["purl"] = purl
+		}
+		if license, ok := change["license"].(string); ok && license != "" {
+			component["licenses"] = []map[string]interface{}{
+				{"license": map[string]interface{}{"id": license}},
+			}
+		}
+		components = append(components, component)
+
+		if vulns, ok := change["vulnerabilities"].([]interface{}); ok {
+			for _, v := range vulns {
+				vuln, ok := v.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				severity, _ := vuln["severity"].(string)
+				vulnerabilities = append(vulnerabilities, map[string]interface{}{
+					"id": vuln["advisory_ghsa_id"],
+					"ratings": []map[string]interface{}{
+						{"severity": cycloneDXSeverity(severity)},
+					},
+					"affects": []map[string]interface{}{
+						{"ref": bomRef},
+					},
+				})
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": fmt.Sprintf("urn:uuid:%s", newUUIDv4()),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		},
+		"components":      components,
+		"vulnerabilities": vulnerabilities,
+	}
+}
+
+// buildSPDXDocument renders the given dependency changes as an SPDX 2.3 SBOM.
+func buildSPDXDocument(changes []map[string]interface{}) map[string]interface{} {
+	packages := make([]map[string]interface{}, 0, len(changes))
+
+	for _, change := range changes {
+		purl, _ := change["package_url"].(string)
+		license, _ := change["license"].(string)
+		if license == "" {
+			license = "NOASSERTION"
+		}
+		downloadLocation, _ := change["source_repository_url"].(string)
+		if downloadLocation == "" {
+			downloadLocation = "NOASSERTION"
+		}
+
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%v@%v", change["name"], change["version"])))
+		pkg := map[string]interface{}{
+			"SPDXID":           fmt.Sprintf("SPDXRef-Package-%s", hex.EncodeToString(hash[:])[:12]),
+			"name":             change["name"],
+			"versionInfo":      change["version"],
+			"downloadLocation": downloadLocation,
+			"licenseConcluded": license,
+		}
+		if purl != "" {
+			pkg["externalRefs"] = []map[string]interface{}{
+				{
+					"referenceCategory": "PACKAGE-MANAGER",
+					"referenceType":     "purl",
+					"referenceLocator":  purl,
+				},
 			}
-			defer func() { _ = resp.Body.Close() }()
+		}
+		packages = append(packages, pkg)
+	}
+
+	return map[string]interface{}{
+		"spdxVersion":       "SPDX-2.3",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"dataLicense":       "CC0-1.0",
+		"name":              "dependency-review-sbom",
+		"documentNamespace": fmt.Sprintf("https://spdx.org/spdxdocs/dependency-review-%s", newUUIDv4()),
+		"creationInfo": map[string]interface{}{
+			"created":  time.Now().UTC().Format(time.RFC3339),
+			"creators": []string{"Tool: github-mcp-server"},
+		},
+		"packages": packages,
+	}
+}
+
+// newUUIDv4 generates a random RFC 4122 version 4 UUID.
+func newUUIDv4() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
+// PostDependencyReviewComment provides a tool to post (or update) a dependency review
+// summary on a pull request, optionally escalating to a formal PR review.
+func PostDependencyReviewComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("post_dependency_review_comment",
+			mcp.WithDescription(t("TOOL_POST_DEPENDENCY_REVIEW_COMMENT_DESCRIPTION", "Post a dependency review summary as a comment (or formal review) on a pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_POST_DEPENDENCY_REVIEW_COMMENT_USER_TITLE", "Post dependency review summary to a pull request"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("The account owner of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("The name of the repository. Required unless repo_url is given."),
+			),
+			mcp.WithNumber("pull_number",
+				mcp.Required(),
+				mcp.Description("The number of the pull request to post the summary to."),
+			),
+			mcp.WithString("basehead",
+				mcp.Description("The base and head Git revisions to compare in the format {base}...{head}. Required unless repo_url carries a compare suffix."),
+			),
+			mcp.WithString("repo_url",
+				mcp.Description("A GitHub repository URL or owner/repo shorthand, optionally carrying the comparison, e.g. https://github.com/octocat/Hello-World/compare/main...feature or octocat/Hello-World@main...feature. Alternative to owner+repo(+basehead)."),
+			),
+			mcp.WithString("name",
+				mcp.Description("The full path, relative to the repository root, of the dependency manifest file."),
+			),
+			mcp.WithString("mode",
+				mcp.Description("How to post the summary: comment, review-request-changes, or review-approve. Defaults to comment."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, basehead, err := resolveRepoAndBasehead(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := requiredInt(request, "pull_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mode, err := OptionalParam[string](request, "mode")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if mode == "" {
+				mode = "comment"
+			}
+			if mode != "comment" && mode != "review-request-changes" && mode != "review-approve" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid mode %q: must be one of comment, review-request-changes, review-approve", mode)), nil
+			}
+
+			dependencyChanges, errResult, err := fetchDependencyChanges(ctx, getClient, owner, repo, basehead, name)
+			if err != nil {
+				return nil, err
+			}
+			if errResult != nil {
+				return errResult, nil
+			}
+
+			body := renderDependencyReviewMarkdown(dependencyChanges) + "\n" + dependencyReviewCommentMarker
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if mode != "comment" {
+				event := "REQUEST_CHANGES"
+				if mode == "review-approve" {
+					event = "APPROVE"
+				}
+				review, resp, err := client.PullRequests.CreateReview(ctx, owner, repo, pullNumber, &github.PullRequestReviewRequest{
+					Body:  github.Ptr(body),
+					Event: github.Ptr(event),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create pull request review: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				result, err := json.Marshal(review)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, fmt.Errorf("failed to marshal pull request review: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get dependency changes: %s", string(body))), nil
+				return mcp.NewToolResultText(string(result)), nil
 			}
 
-			result, err := json.Marshal(dependencyChanges)
+			existing, err := findDependencyReviewComment(ctx, client, owner, repo, pullNumber)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal dependency changes: %w", err)
+				return nil, err
+			}
+
+			var comment *github.IssueComment
+			if existing != nil {
+				comment, _, err = client.Issues.EditComment(ctx, owner, repo, existing.GetID(), &github.IssueComment{Body: github.Ptr(body)})
+				if err != nil {
+					return nil, fmt.Errorf("failed to update dependency review comment: %w", err)
+				}
+			} else {
+				comment, _, err = client.Issues.CreateComment(ctx, owner, repo, pullNumber, &github.IssueComment{Body: github.Ptr(body)})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create dependency review comment: %w", err)
+				}
+			}
+
+			result, err := json.Marshal(comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal dependency review comment: %w", err)
 			}
 
 			return mcp.NewToolResultText(string(result)), nil
 		}
 }
+
+// findDependencyReviewComment looks for a prior comment on the pull request carrying
+// the dependencyReviewCommentMarker, so the tool can update it instead of posting a
+// duplicate every run.
+func findDependencyReviewComment(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, pullNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request comments: %w", err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), dependencyReviewCommentMarker) {
+				return comment, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// renderDependencyReviewMarkdown builds the Markdown summary posted to a pull request:
+// added/removed package tables grouped by ecosystem, a vulnerabilities section, and a
+// license-change section.
+func renderDependencyReviewMarkdown(changes []map[string]interface{}) string {
+	var b strings.Builder
+
+	b.WriteString("## Dependency Review\n\n")
+
+	renderPackageTable(&b, "Added", changes, "added")
+	renderPackageTable(&b, "Removed", changes, "removed")
+
+	b.WriteString("### Vulnerabilities Introduced\n\n")
+	vulnCount := 0
+	for _, change := range changes {
+		if change["change_type"] != "added" {
+			continue
+		}
+		vulns, ok := change["vulnerabilities"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range vulns {
+			vuln, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			vulnCount++
+			severity, _ := vuln["severity"].(string)
+			badge := severityBadges[severity]
+			if badge == "" {
+				badge = severity
+			}
+			b.WriteString(fmt.Sprintf("- **%v** (%v@%v) — %s — [%v](%v)\n",
+				vuln["advisory_ghsa_id"], change["name"], change["version"], badge, vuln["advisory_ghsa_id"], vuln["advisory_url"]))
+		}
+	}
+	if vulnCount == 0 {
+		b.WriteString("None.\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("### License Changes\n\n")
+	licenseCount := 0
+	for _, change := range changes {
+		license, _ := change["license"].(string)
+		if license == "" {
+			license = "unknown"
+		}
+		b.WriteString(fmt.Sprintf("- %v %v@%v: `%s`\n", change["change_type"], change["name"], change["version"], license))
+		licenseCount++
+	}
+	if licenseCount == 0 {
+		b.WriteString("None.\n")
+	}
+
+	return b.String()
+}
+
+// renderPackageTable writes a Markdown table of changes matching changeType, grouped by
+// ecosystem, under the given heading.
+func renderPackageTable(b *strings.Builder, heading string, changes []map[string]interface{}, changeType string) {
+	byEcosystem := map[string][]map[string]interface{}{}
+	for _, change := range changes {
+		if change["change_type"] != changeType {
+			continue
+		}
+		ecosystem, _ := change["ecosystem"].(string)
+		byEcosystem[ecosystem] = append(byEcosystem[ecosystem], change)
+	}
+
+	b.WriteString(fmt.Sprintf("### %s\n\n", heading))
+	if len(byEcosystem) == 0 {
+		b.WriteString("None.\n\n")
+		return
+	}
+
+	ecosystems := make([]string, 0, len(byEcosystem))
+	for ecosystem := range byEcosystem {
+		ecosystems = append(ecosystems, ecosystem)
+	}
+	sort.Strings(ecosystems)
+
+	for _, ecosystem := range ecosystems {
+		b.WriteString(fmt.Sprintf("**%s**\n\n", ecosystem))
+		b.WriteString("| Package | Version | Manifest |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, change := range byEcosystem[ecosystem] {
+			b.WriteString(fmt.Sprintf("| %v | %v | %v |\n", change["name"], change["version"], change["manifest"]))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -29,8 +30,9 @@ func Test_GetDependencyReviewCompare(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "basehead")
+	assert.Contains(t, tool.InputSchema.Properties, "repo_url")
 	assert.Contains(t, tool.InputSchema.Properties, "name")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "basehead"})
+	assert.Empty(t, tool.InputSchema.Required)
 
 	// Setup mock dependency changes for success case
 	mockDependencyChanges := []map[string]interface{}{
@@ -165,27 +167,27 @@ func Test_GetDependencyReviewCompare(t *testing.T) {
 			expectedErrMsg: "failed to get dependency changes",
 		},
 		{
-			name:         "missing required parameter owner",
+			name:         "missing owner and repo_url",
 			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
 				"repo":     "repo",
 				"basehead": "main...feature",
 			},
 			expectError:    true,
-			expectedErrMsg: "missing required parameter: owner",
+			expectedErrMsg: "missing required parameter: owner and repo",
 		},
 		{
-			name:         "missing required parameter repo",
+			name:         "missing repo and repo_url",
 			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
 				"owner":    "owner",
 				"basehead": "main...feature",
 			},
 			expectError:    true,
-			expectedErrMsg: "missing required parameter: repo",
+			expectedErrMsg: "missing required parameter: owner and repo",
 		},
 		{
-			name:         "missing required parameter basehead",
+			name:         "missing basehead and repo_url compare suffix",
 			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
@@ -194,6 +196,25 @@ func Test_GetDependencyReviewCompare(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "missing required parameter: basehead",
 		},
+		{
+			name: "repo_url carries owner, repo, and basehead",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					expectPath(
+						t,
+						"/repos/owner/repo/dependency-graph/compare/main...feature",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockDependencyChanges),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"repo_url": "https://github.com/owner/repo/compare/main...feature",
+			},
+			expectError:     false,
+			expectedChanges: mockDependencyChanges,
+		},
 	}
 
 	for _, tc := range tests {
@@ -242,3 +263,658 @@ func Test_GetDependencyReviewCompare(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetDependencyReviewCompare_Scorecard(t *testing.T) {
+	mockDependencyChanges := []map[string]interface{}{
+		{
+			"change_type":           "added",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "left-pad",
+			"version":               "1.3.0",
+			"package_url":           "pkg:npm/left-pad@1.3.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/left-pad/left-pad",
+			"vulnerabilities":       []interface{}{},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "no-scorecard",
+			"version":               "1.0.0",
+			"package_url":           "pkg:npm/no-scorecard@1.0.0",
+			"license":               "MIT",
+			"source_repository_url": "https://example.com/no-scorecard/no-scorecard",
+			"vulnerabilities":       []interface{}{},
+		},
+	}
+
+	scorecardServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/left-pad/left-pad" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"date":  "2026-01-01",
+			"score": 7.8,
+			"checks": []map[string]interface{}{
+				{"name": "Maintained", "score": 10},
+				{"name": "Code-Review", "score": 5},
+				{"name": "Some-Other-Check", "score": 1},
+			},
+		})
+	}))
+	defer scorecardServer.Close()
+
+	originalBaseURL := scorecardAPIBaseURL
+	originalClient := scorecardHTTPClient
+	scorecardAPIBaseURL = scorecardServer.URL
+	scorecardHTTPClient = scorecardServer.Client()
+	t.Cleanup(func() {
+		scorecardAPIBaseURL = originalBaseURL
+		scorecardHTTPClient = originalClient
+	})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+			mockResponse(t, http.StatusOK, mockDependencyChanges),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := GetDependencyReviewCompare(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]interface{}{
+		"owner":             "owner",
+		"repo":              "repo",
+		"basehead":          "main...feature",
+		"include_scorecard": true,
+		"scorecard_checks":  []interface{}{"Maintained", "Code-Review"},
+	})
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	textContent := getTextResult(t, result)
+	var returnedChanges []map[string]interface{}
+	err = json.Unmarshal([]byte(textContent.Text), &returnedChanges)
+	require.NoError(t, err)
+	require.Len(t, returnedChanges, 2)
+
+	scorecard, ok := returnedChanges[0]["scorecard"].(map[string]interface{})
+	require.True(t, ok, "expected a scorecard object for left-pad")
+	assert.Equal(t, 7.8, scorecard["score"])
+	assert.Equal(t, "2026-01-01", scorecard["date"])
+	checks, ok := scorecard["checks"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(10), checks["Maintained"])
+	assert.Equal(t, float64(5), checks["Code-Review"])
+	assert.NotContains(t, checks, "Some-Other-Check")
+
+	assert.Nil(t, returnedChanges[1]["scorecard"])
+}
+
+func Test_PostDependencyReviewComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PostDependencyReviewComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "post_dependency_review_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "pull_number")
+	assert.Contains(t, tool.InputSchema.Properties, "repo_url")
+	assert.Contains(t, tool.InputSchema.Properties, "mode")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"pull_number"})
+
+	mockDependencyChanges := []map[string]interface{}{
+		{
+			"change_type":           "added",
+			"manifest":              "Gemfile",
+			"ecosystem":             "rubygems",
+			"name":                  "ruby-openid",
+			"version":               "2.7.0",
+			"package_url":           "pkg:gem/ruby-openid@2.7.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/openid/ruby-openid",
+			"vulnerabilities": []interface{}{
+				map[string]interface{}{
+					"severity":         "critical",
+					"advisory_ghsa_id": "GHSA-fqfj-cmh6-hj49",
+					"advisory_summary": "Ruby OpenID",
+					"advisory_url":     "https://github.com/advisories/GHSA-fqfj-cmh6-hj49",
+				},
+			},
+		},
+	}
+
+	t.Run("creates a new comment when none exists", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+				mockResponse(t, http.StatusOK, mockDependencyChanges),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, []*github.IssueComment{}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusCreated, &github.IssueComment{
+					ID:   github.Ptr(int64(1)),
+					Body: github.Ptr("## Dependency Review\n" + dependencyReviewCommentMarker),
+				}),
+			),
+		)
+		client := github.NewClient(mockedClient)
+		_, handler := PostDependencyReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pull_number": float64(1),
+			"basehead":    "main...feature",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		textContent := getTextResult(t, result)
+		var comment github.IssueComment
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comment))
+		assert.Contains(t, comment.GetBody(), dependencyReviewCommentMarker)
+	})
+
+	t.Run("updates an existing dependency review comment", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+				mockResponse(t, http.StatusOK, mockDependencyChanges),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, []*github.IssueComment{
+					{
+						ID:   github.Ptr(int64(42)),
+						Body: github.Ptr("## Dependency Review\nstale\n" + dependencyReviewCommentMarker),
+					},
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposIssuesCommentsByOwnerByRepoByCommentId,
+				mockResponse(t, http.StatusOK, &github.IssueComment{
+					ID:   github.Ptr(int64(42)),
+					Body: github.Ptr("## Dependency Review\n" + dependencyReviewCommentMarker),
+				}),
+			),
+		)
+		client := github.NewClient(mockedClient)
+		_, handler := PostDependencyReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pull_number": float64(1),
+			"basehead":    "main...feature",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		textContent := getTextResult(t, result)
+		var comment github.IssueComment
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comment))
+		assert.Equal(t, int64(42), comment.GetID())
+	})
+
+	t.Run("review-request-changes posts a formal pull request review", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+				mockResponse(t, http.StatusOK, mockDependencyChanges),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposPullsReviewsByOwnerByRepoByPullNumber,
+				mockResponse(t, http.StatusOK, &github.PullRequestReview{
+					ID:    github.Ptr(int64(7)),
+					State: github.Ptr("CHANGES_REQUESTED"),
+				}),
+			),
+		)
+		client := github.NewClient(mockedClient)
+		_, handler := PostDependencyReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pull_number": float64(1),
+			"basehead":    "main...feature",
+			"mode":        "review-request-changes",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		textContent := getTextResult(t, result)
+		var review github.PullRequestReview
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &review))
+		assert.Equal(t, "CHANGES_REQUESTED", review.GetState())
+	})
+
+	t.Run("invalid mode is rejected", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient())
+		_, handler := PostDependencyReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":       "owner",
+			"repo":        "repo",
+			"pull_number": float64(1),
+			"basehead":    "main...feature",
+			"mode":        "bogus",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Contains(t, getTextResult(t, result).Text, "invalid mode")
+	})
+}
+
+func Test_EvaluateDependencyReview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := EvaluateDependencyReview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "evaluate_dependency_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "basehead")
+	assert.Contains(t, tool.InputSchema.Properties, "fail_on_severity")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_licenses")
+	assert.Contains(t, tool.InputSchema.Properties, "deny_licenses")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_ghsas")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_dependencies_licenses")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockDependencyChanges := []map[string]interface{}{
+		{
+			"change_type":           "removed",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "helmet",
+			"version":               "4.6.0",
+			"package_url":           "pkg:npm/helmet@4.6.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/helmetjs/helmet",
+			"vulnerabilities":       []interface{}{},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "Gemfile",
+			"ecosystem":             "rubygems",
+			"name":                  "ruby-openid",
+			"version":               "2.7.0",
+			"package_url":           "pkg:gem/ruby-openid@2.7.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/openid/ruby-openid",
+			"vulnerabilities": []interface{}{
+				map[string]interface{}{
+					"severity":         "critical",
+					"advisory_ghsa_id": "GHSA-fqfj-cmh6-hj49",
+					"advisory_summary": "Ruby OpenID",
+					"advisory_url":     "https://github.com/advisories/GHSA-fqfj-cmh6-hj49",
+				},
+			},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "requirements.txt",
+			"ecosystem":             "pip",
+			"name":                  "obscure-pkg",
+			"version":               "1.0.0",
+			"package_url":           "pkg:pypi/obscure-pkg@1.0.0",
+			"license":               nil,
+			"source_repository_url": "https://github.com/example/obscure-pkg",
+			"vulnerabilities":       []interface{}{},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "left-pad",
+			"version":               "1.3.0",
+			"package_url":           "pkg:npm/left-pad@1.3.0",
+			"license":               "GPL-3.0",
+			"source_repository_url": "https://github.com/left-pad/left-pad",
+			"vulnerabilities":       []interface{}{},
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		requestArgs            map[string]interface{}
+		expectedPassed         bool
+		expectedVulnViolations int
+		expectedLicViolations  int
+		expectedPassingChanges int
+	}{
+		{
+			name: "default policy flags critical vulnerability and unknown license",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"basehead": "main...feature",
+			},
+			expectedPassed:         false,
+			expectedVulnViolations: 1,
+			expectedLicViolations:  1,
+			expectedPassingChanges: 1,
+		},
+		{
+			name: "allow_ghsas and allow_dependencies_licenses clear violations",
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"basehead":                    "main...feature",
+				"allow_ghsas":                 []interface{}{"GHSA-fqfj-cmh6-hj49"},
+				"allow_dependencies_licenses": []interface{}{"pkg:pypi/obscure-pkg"},
+			},
+			expectedPassed:         true,
+			expectedVulnViolations: 0,
+			expectedLicViolations:  0,
+			expectedPassingChanges: 3,
+		},
+		{
+			name: "deny_licenses rejects an otherwise passing license",
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"basehead":      "main...feature",
+				"deny_licenses": []interface{}{"GPL-3.0"},
+				"allow_ghsas":   []interface{}{"GHSA-fqfj-cmh6-hj49"},
+			},
+			expectedPassed:         false,
+			expectedVulnViolations: 0,
+			expectedLicViolations:  2,
+			expectedPassingChanges: 1,
+		},
+		{
+			name: "fail_on_severity critical still flags a critical vulnerability",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"basehead":         "main...feature",
+				"fail_on_severity": "critical",
+			},
+			expectedPassed:         false,
+			expectedVulnViolations: 1,
+			expectedLicViolations:  1,
+			expectedPassingChanges: 1,
+		},
+		{
+			name: "invalid fail_on_severity is rejected",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"basehead":         "main...feature",
+				"fail_on_severity": "severe",
+			},
+			expectedPassed: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusOK, mockDependencyChanges),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			_, handler := EvaluateDependencyReview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			textContent := getTextResult(t, result)
+
+			if tc.name == "invalid fail_on_severity is rejected" {
+				assert.Contains(t, textContent.Text, "invalid fail_on_severity")
+				return
+			}
+
+			var evaluation struct {
+				Passed                  bool                     `json:"passed"`
+				VulnerabilityViolations []map[string]interface{} `json:"vulnerability_violations"`
+				LicenseViolations       []map[string]interface{} `json:"license_violations"`
+				PassingChanges          []map[string]interface{} `json:"passing_changes"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &evaluation)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedPassed, evaluation.Passed)
+			assert.Len(t, evaluation.VulnerabilityViolations, tc.expectedVulnViolations)
+			assert.Len(t, evaluation.LicenseViolations, tc.expectedLicViolations)
+			assert.Len(t, evaluation.PassingChanges, tc.expectedPassingChanges)
+		})
+	}
+}
+
+func Test_GetDependencyReviewSBOM(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetDependencyReviewSBOM(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_dependency_review_sbom", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.Contains(t, tool.InputSchema.Properties, "scope")
+	assert.Contains(t, tool.InputSchema.Properties, "repo_url")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockDependencyChanges := []map[string]interface{}{
+		{
+			"change_type":           "removed",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "helmet",
+			"version":               "4.6.0",
+			"package_url":           "pkg:npm/helmet@4.6.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/helmetjs/helmet",
+			"vulnerabilities":       []interface{}{},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "Gemfile",
+			"ecosystem":             "rubygems",
+			"name":                  "ruby-openid",
+			"version":               "2.7.0",
+			"package_url":           "pkg:gem/ruby-openid@2.7.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/openid/ruby-openid",
+			"vulnerabilities": []interface{}{
+				map[string]interface{}{
+					"severity":         "critical",
+					"advisory_ghsa_id": "GHSA-fqfj-cmh6-hj49",
+					"advisory_summary": "Ruby OpenID",
+					"advisory_url":     "https://github.com/advisories/GHSA-fqfj-cmh6-hj49",
+				},
+				map[string]interface{}{
+					"severity":         "moderate",
+					"advisory_ghsa_id": "GHSA-xxxx-yyyy-zzzz",
+					"advisory_summary": "Ruby OpenID moderate issue",
+					"advisory_url":     "https://github.com/advisories/GHSA-xxxx-yyyy-zzzz",
+				},
+			},
+		},
+	}
+
+	mockVersionBumpChanges := []map[string]interface{}{
+		{
+			"change_type":           "removed",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "left-pad",
+			"version":               "1.0.0",
+			"package_url":           "pkg:npm/left-pad@1.0.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/left-pad/left-pad",
+			"vulnerabilities":       []interface{}{},
+		},
+		{
+			"change_type":           "added",
+			"manifest":              "package.json",
+			"ecosystem":             "npm",
+			"name":                  "left-pad",
+			"version":               "2.0.0",
+			"package_url":           "pkg:npm/left-pad@2.0.0",
+			"license":               "MIT",
+			"source_repository_url": "https://github.com/left-pad/left-pad",
+			"vulnerabilities":       []interface{}{},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "default format and scope produce a CycloneDX document",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"basehead": "main...feature",
+			},
+		},
+		{
+			name: "spdx-json format with all scope",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"basehead": "main...feature",
+				"format":   "spdx-json",
+				"scope":    "all",
+			},
+		},
+		{
+			name: "invalid format is rejected",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"basehead": "main...feature",
+				"format":   "bad-format",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid format",
+		},
+		{
+			name: "invalid scope is rejected",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"basehead": "main...feature",
+				"scope":    "bad-scope",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid scope",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusOK, mockDependencyChanges),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			_, handler := GetDependencyReviewSBOM(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			format, _ := tc.requestArgs["format"].(string)
+			var doc map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &doc)
+			require.NoError(t, err)
+
+			if format == "spdx-json" {
+				assert.Equal(t, "SPDX-2.3", doc["spdxVersion"])
+				assert.Equal(t, "SPDXRef-DOCUMENT", doc["SPDXID"])
+				assert.NotEmpty(t, doc["packages"])
+
+				creationInfo, ok := doc["creationInfo"].(map[string]interface{})
+				require.True(t, ok)
+				assert.NotEmpty(t, creationInfo["creators"])
+			} else {
+				assert.Equal(t, "CycloneDX", doc["bomFormat"])
+				assert.Equal(t, "1.5", doc["specVersion"])
+				assert.NotEmpty(t, doc["serialNumber"])
+				assert.NotEmpty(t, doc["components"])
+
+				vulnerabilities, ok := doc["vulnerabilities"].([]interface{})
+				require.True(t, ok)
+				require.Len(t, vulnerabilities, 2)
+				severities := make([]string, 0, len(vulnerabilities))
+				for _, v := range vulnerabilities {
+					vuln, ok := v.(map[string]interface{})
+					require.True(t, ok)
+					ratings, ok := vuln["ratings"].([]interface{})
+					require.True(t, ok)
+					rating, ok := ratings[0].(map[string]interface{})
+					require.True(t, ok)
+					severities = append(severities, rating["severity"].(string))
+				}
+				// GitHub's "moderate" has no CycloneDX equivalent and must map to "medium".
+				assert.Contains(t, severities, "critical")
+				assert.Contains(t, severities, "medium")
+				assert.NotContains(t, severities, "moderate")
+			}
+		})
+	}
+
+	t.Run("net-new scope excludes a version bump of an existing dependency", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+				mockResponse(t, http.StatusOK, mockVersionBumpChanges),
+			),
+		)
+		client := github.NewClient(mockedClient)
+		_, handler := GetDependencyReviewSBOM(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":    "owner",
+			"repo":     "repo",
+			"basehead": "main...feature",
+			"format":   "spdx-json",
+			"scope":    "net-new",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &doc))
+
+		// left-pad already existed (it was only bumped from 1.0.0 to 2.0.0), so it must
+		// not be reported as net-new.
+		assert.Empty(t, doc["packages"])
+	})
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RepoRef identifies a GitHub repository independent of the host it lives on
+// (github.com or a GitHub Enterprise Server instance).
+type RepoRef struct {
+	Owner string
+	Repo  string
+	Host  string
+}
+
+// RepoRefParseError reports a malformed repository reference, carrying the original
+// input so callers can surface a precise error back to the user.
+type RepoRefParseError struct {
+	Input  string
+	Reason string
+}
+
+func (e *RepoRefParseError) Error() string {
+	return fmt.Sprintf("invalid repository reference %q: %s", e.Input, e.Reason)
+}
+
+// ParseRepoRef parses a repository reference into owner, repo, and host. It accepts:
+//   - a full URL, e.g. https://github.com/octocat/Hello-World, with an optional
+//     trailing compare/<basehead>, tree/<ref>, or commit/<sha> suffix
+//   - GitHub Enterprise Server hostnames
+//   - a trailing .git suffix
+//   - bare owner/repo shorthand, e.g. octocat/Hello-World, optionally followed by
+//     @<basehead>
+//
+// Any compare/tree/commit/basehead suffix is ignored here; callers that need it should
+// extract it from the original input separately.
+func ParseRepoRef(ref string) (RepoRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return RepoRef{}, &RepoRefParseError{Input: ref, Reason: "empty repository reference"}
+	}
+
+	if strings.Contains(ref, "://") {
+		return parseRepoRefURL(ref)
+	}
+
+	shorthand, _, _ := strings.Cut(ref, "@")
+	parts := strings.Split(shorthand, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return RepoRef{}, &RepoRefParseError{Input: ref, Reason: "expected owner/repo shorthand or a GitHub URL"}
+	}
+
+	return RepoRef{
+		Owner: parts[0],
+		Repo:  strings.TrimSuffix(parts[1], ".git"),
+		Host:  "github.com",
+	}, nil
+}
+
+func parseRepoRefURL(ref string) (RepoRef, error) {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return RepoRef{}, &RepoRefParseError{Input: ref, Reason: err.Error()}
+	}
+	if parsed.Host == "" {
+		return RepoRef{}, &RepoRefParseError{Input: ref, Reason: "missing host"}
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return RepoRef{}, &RepoRefParseError{Input: ref, Reason: "expected /owner/repo in the URL path"}
+	}
+
+	return RepoRef{
+		Owner: parts[0],
+		Repo:  strings.TrimSuffix(parts[1], ".git"),
+		Host:  parsed.Host,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRepoRef(t *testing.T) {
+	tests := []struct {
+		name          string
+		ref           string
+		expected      RepoRef
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name: "bare owner/repo shorthand",
+			ref:  "octocat/Hello-World",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "shorthand with basehead suffix is ignored",
+			ref:  "octocat/Hello-World@main...feature",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "github.com URL",
+			ref:  "https://github.com/octocat/Hello-World",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "github.com URL with trailing .git",
+			ref:  "https://github.com/octocat/Hello-World.git",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "github.com URL with compare suffix",
+			ref:  "https://github.com/octocat/Hello-World/compare/main...feature",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "github.com URL with tree suffix",
+			ref:  "https://github.com/octocat/Hello-World/tree/main",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "github.com URL with commit suffix",
+			ref:  "https://github.com/octocat/Hello-World/commit/abc1234",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.com",
+			},
+		},
+		{
+			name: "GitHub Enterprise Server hostname",
+			ref:  "https://github.example.com/octocat/Hello-World",
+			expected: RepoRef{
+				Owner: "octocat",
+				Repo:  "Hello-World",
+				Host:  "github.example.com",
+			},
+		},
+		{
+			name:          "empty reference",
+			ref:           "",
+			expectError:   true,
+			errorContains: "empty repository reference",
+		},
+		{
+			name:          "shorthand missing repo",
+			ref:           "octocat",
+			expectError:   true,
+			errorContains: "expected owner/repo shorthand",
+		},
+		{
+			name:          "URL missing repo path segment",
+			ref:           "https://github.com/octocat",
+			expectError:   true,
+			errorContains: "expected /owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseRepoRef(tc.ref)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}